@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Alias 为已注册的子命令 primary 添加一个或多个别名
+//
+// 别名可以像真实的子命令名称一样被 [CmdOpt.Exec] 和 [CmdOpt.Command] 解析，
+// 并在 {{commands}} 中以 name, a1, a2   title 的形式列出。如果别名与已有的
+// 子命令名称或别名冲突，将会 panic。
+func (opt *CmdOpt) Alias(primary string, aliases ...string) {
+	cmd, found := opt.commands[primary]
+	if !found {
+		panic(fmt.Sprintf("不存在名为 %s 的子命令", primary))
+	}
+	if cmd.primary != primary {
+		panic(fmt.Sprintf("%s 本身是一个别名，不能再为其添加别名", primary))
+	}
+
+	for _, alias := range aliases {
+		if _, found := opt.commands[alias]; found {
+			panic(fmt.Sprintf("存在相同名称的子命令或别名：%s", alias))
+		}
+	}
+
+	for _, alias := range aliases {
+		opt.commands[alias] = cmd
+		cmd.aliases = append(cmd.aliases, alias)
+	}
+
+	if display := displayName(primary, cmd.aliases); len(display) > opt.maxCmdLen {
+		opt.maxCmdLen = len(display)
+	}
+}
+
+// displayName 返回在 {{commands}} 中展示的名称，包含别名
+func displayName(name string, aliases []string) string {
+	if len(aliases) == 0 {
+		return name
+	}
+	return name + ", " + strings.Join(aliases, ", ")
+}
+
+// suggest 在找不到名为 name 的子命令时，返回编辑距离最接近的若干个候选名称
+//
+// 候选按编辑距离从小到大排序，距离相同时按字典序排序，最多返回 3 个。
+func (opt *CmdOpt) suggest(name string) []string {
+	threshold := len(name) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	type candidate struct {
+		name string
+		dist int
+	}
+	var candidates []candidate
+
+	for key := range opt.commands {
+		if diff := len(key) - len(name); diff > threshold || diff < -threshold {
+			continue // 长度差距过大，提前跳过，无需计算编辑距离
+		}
+
+		if d := damerauLevenshtein(name, key); d <= threshold {
+			candidates = append(candidates, candidate{name: key, dist: d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	if len(candidates) > 3 {
+		candidates = candidates[:3]
+	}
+
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		names[i] = c.name
+	}
+	return names
+}
+
+// damerauLevenshtein 计算 a 和 b 之间的 Damerau-Levenshtein 编辑距离
+//
+// 插入、删除、替换和换位的代价均为 1，按字符（rune）而非字节计算。
+func damerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+
+	d := make([][]int, la+1)
+	for i := range d {
+		d[i] = make([]int, lb+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= lb; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if t := d[i-2][j-2] + cost; t < d[i][j] {
+					d[i][j] = t // 相邻两个字符换位
+				}
+			}
+		}
+	}
+
+	return d[la][lb]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
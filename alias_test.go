@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestCmdOpt_Alias(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "header\n{{commands}}\nfooter", nil, notFound)
+
+	opt.New("checkout", "checkout title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte("checkout"))
+			return err
+		}
+	})
+	opt.Alias("checkout", "co")
+
+	a.Equal(opt.Commands(), []string{"checkout"})
+
+	title, _, found := opt.Command("co")
+	a.True(found).Equal(title, "checkout title")
+
+	a.PanicString(func() {
+		opt.Alias("checkout", "co")
+	}, "存在相同名称的子命令或别名：co")
+
+	a.PanicString(func() {
+		opt.Alias("not-exists", "x")
+	}, "不存在名为 not-exists 的子命令")
+
+	a.True(strings.Contains(opt.Usage(), "checkout, co"))
+
+	// 别名能触发 Exec
+	output.Reset()
+	opt2, output2 := newAliasTestOpt(a)
+	a.NotError(opt2.Exec([]string{"co"}))
+	a.Equal(output2.String(), "checkout")
+}
+
+func newAliasTestOpt(a *assert.Assertion) (*CmdOpt, *bytes.Buffer) {
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "usage", nil, notFound)
+	opt.New("checkout", "checkout title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte("checkout"))
+			return err
+		}
+	})
+	opt.Alias("checkout", "co")
+	return opt, output
+}
+
+func TestCmdOpt_suggest(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+	opt.New("checkout", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(io.Writer) error { return nil }
+	})
+	opt.New("commit", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(io.Writer) error { return nil }
+	})
+
+	a.NotError(opt.Exec([]string{"checkut"}))
+	a.True(strings.Contains(output.String(), "Did you mean: checkout?"))
+}
+
+func TestDamerauLevenshtein(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(damerauLevenshtein("checkout", "checkout"), 0)
+	a.Equal(damerauLevenshtein("checkout", "checkut"), 1)
+	a.Equal(damerauLevenshtein("ab", "ba"), 1)
+	a.Equal(damerauLevenshtein("", "abc"), 3)
+}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bind 根据 v 的结构体标签将其字段注册为 fs 的参数
+//
+// v 必须是指向结构体的指针，支持在字段上使用以下标签：
+//   - flag：参数名称，比如 `flag:"-f,--file"`，可以用逗号分隔多个名称，
+//     它们将指向同一个字段；
+//   - default：参数的默认值，按字段类型解析；对于 []string 类型，
+//     使用英文逗号分隔多个值，比如 `default:"a,b"`；
+//   - env：当该参数未在命令行中指定时，从此环境变量读取值作为默认值，
+//     优先级高于 default；
+//   - usage：参数的说明内容；
+//   - required：取值为 true 时表示该参数必须指定，否则返回的 [DoFunc]
+//     在执行时会返回错误；通过 env 标签取得值时也视为已指定；
+//   - args：取值为 rest 时，表示将未被解析的位置参数保存至该字段，
+//     字段类型必须为 []string。
+//
+// 支持的字段类型为 bool、int、int64、uint、uint64、float64、string、
+// time.Duration、[]string（可重复指定）以及同时实现了 [encoding.TextUnmarshaler]
+// 和 [encoding.TextMarshaler] 的类型，仅实现 TextUnmarshaler 的类型会 panic。
+//
+// do 为参数解析完成之后真正执行的逻辑，可以为 nil。
+func Bind(fs FlagSet, v any, do DoFunc) DoFunc {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic("参数 v 必须是指向结构体的指针")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	if do == nil {
+		do = func(io.Writer) error { return nil }
+	}
+
+	// requiredField 记录了一个 required 字段的全部别名，以及该字段是否已经
+	// 通过 env 标签获得了取值，这两者都只能在绑定时确定，不能事后从 fs.Visit 反推。
+	type requiredField struct {
+		names        []string
+		satisfiedEnv bool
+	}
+	var required []requiredField
+	var rest reflect.Value
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		if field.Tag.Get("args") == "rest" {
+			if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.String {
+				panic(fmt.Sprintf("字段 %s 标记了 args:\"rest\"，但类型不为 []string", field.Name))
+			}
+			rest = fv
+			continue
+		}
+
+		names := parseFlagNames(field.Tag.Get("flag"))
+		if len(names) == 0 {
+			continue
+		}
+
+		def := field.Tag.Get("default")
+		satisfiedEnv := false
+		if env := field.Tag.Get("env"); env != "" {
+			if val, found := os.LookupEnv(env); found {
+				def = val
+				satisfiedEnv = true
+			}
+		}
+
+		bindField(fs, fv, field.Name, names, field.Tag.Get("usage"), def)
+
+		if field.Tag.Get("required") == "true" {
+			required = append(required, requiredField{names: names, satisfiedEnv: satisfiedEnv})
+		}
+	}
+
+	return func(w io.Writer) error {
+		if len(required) > 0 {
+			set := make(map[string]bool, fs.NFlag())
+			fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+			var missing []string
+			for _, r := range required {
+				if r.satisfiedEnv {
+					continue
+				}
+
+				satisfied := false
+				for _, name := range r.names {
+					if set[name] {
+						satisfied = true
+						break
+					}
+				}
+				if !satisfied {
+					missing = append(missing, r.names[0])
+				}
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("缺少必须的参数：%s", strings.Join(missing, ", "))
+			}
+		}
+
+		if rest.IsValid() {
+			rest.Set(reflect.ValueOf(fs.Args()))
+		}
+
+		return do(w)
+	}
+}
+
+// Register 将 [Bind] 与 [CmdOpt.New] 结合，免去在 [CommandFunc] 中手动调用
+// fs.StringVar 等方法绑定参数的步骤，具体的标签说明可参考 [Bind]。
+//
+// v 必须是指向结构体的指针；do 为参数绑定完成之后真正执行的逻辑，可以为 nil。
+func (opt *CmdOpt) Register(name, title, usage string, v any, do DoFunc) {
+	opt.New(name, title, usage, func(fs *flag.FlagSet) DoFunc {
+		return Bind(fs, v, do)
+	})
+}
+
+func parseFlagNames(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, ",")
+	names := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimLeft(strings.TrimSpace(p), "-")
+		if p != "" {
+			names = append(names, p)
+		}
+	}
+	return names
+}
+
+func bindField(fs FlagSet, fv reflect.Value, fieldName string, names []string, usage, def string) {
+	switch p := fv.Addr().Interface().(type) {
+	case *bool:
+		val, err := strconv.ParseBool(orDefault(def, "false"))
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 bool 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.BoolVar(p, name, val, usage)
+		}
+	case *int:
+		val, err := strconv.Atoi(orDefault(def, "0"))
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 int 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.IntVar(p, name, val, usage)
+		}
+	case *int64:
+		val, err := strconv.ParseInt(orDefault(def, "0"), 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 int64 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.Int64Var(p, name, val, usage)
+		}
+	case *uint:
+		val, err := strconv.ParseUint(orDefault(def, "0"), 10, 0)
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 uint 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.UintVar(p, name, uint(val), usage)
+		}
+	case *uint64:
+		val, err := strconv.ParseUint(orDefault(def, "0"), 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 uint64 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.Uint64Var(p, name, val, usage)
+		}
+	case *float64:
+		val, err := strconv.ParseFloat(orDefault(def, "0"), 64)
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 float64 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.Float64Var(p, name, val, usage)
+		}
+	case *string:
+		for _, name := range names {
+			fs.StringVar(p, name, def, usage)
+		}
+	case *time.Duration:
+		val, err := time.ParseDuration(orDefault(def, "0s"))
+		if err != nil {
+			panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的 time.Duration 值", fieldName, def))
+		}
+		for _, name := range names {
+			fs.DurationVar(p, name, val, usage)
+		}
+	case *[]string:
+		if def != "" {
+			*p = append(*p, strings.Split(def, ",")...)
+		}
+		replaced := false // 命令行中首次指定时替换掉默认值
+		for _, name := range names {
+			fs.Func(name, usage, func(s string) error {
+				if !replaced {
+					*p = nil
+					replaced = true
+				}
+				*p = append(*p, s)
+				return nil
+			})
+		}
+	case encoding.TextUnmarshaler:
+		m, ok := fv.Addr().Interface().(encoding.TextMarshaler)
+		if !ok {
+			panic(fmt.Sprintf("字段 %s 实现了 TextUnmarshaler 但未实现 TextMarshaler", fieldName))
+		}
+		if def != "" {
+			if err := p.UnmarshalText([]byte(def)); err != nil {
+				panic(fmt.Sprintf("字段 %s 的 default 标签 %q 不是合法的值", fieldName, def))
+			}
+		}
+		for _, name := range names {
+			fs.TextVar(p, name, m, usage)
+		}
+	default:
+		panic(fmt.Sprintf("字段 %s 的类型不受支持", fieldName))
+	}
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+)
+
+type bindTestOptions struct {
+	File    string        `flag:"-f,--file" default:"a.txt" usage:"file usage"`
+	Count   int           `flag:"-c,--count" default:"1" usage:"count usage"`
+	Verbose bool          `flag:"-v,--verbose" usage:"verbose usage"`
+	Timeout time.Duration `flag:"--timeout" default:"1s" usage:"timeout usage"`
+	Name    string        `flag:"--name" required:"true" usage:"name usage"`
+	Tags    []string      `flag:"--tag" default:"a,b" usage:"tag usage"`
+	Rest    []string      `args:"rest"`
+}
+
+func TestBind(t *testing.T) {
+	a := assert.New(t, false)
+
+	opt := &bindTestOptions{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := new(bytes.Buffer)
+
+	do := Bind(fs, opt, func(w io.Writer) error {
+		_, err := w.Write([]byte(opt.File))
+		return err
+	})
+
+	a.NotError(fs.Parse([]string{"--name", "n1", "-c", "5", "rest1", "rest2"}))
+	a.NotError(do(output))
+	a.Equal(output.String(), "a.txt").
+		Equal(opt.Count, 5).
+		Equal(opt.Name, "n1").
+		Equal(opt.Rest, []string{"rest1", "rest2"}).
+		Equal(opt.Tags, []string{"a", "b"})
+}
+
+func TestBind_required(t *testing.T) {
+	a := assert.New(t, false)
+
+	opt := &bindTestOptions{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	do := Bind(fs, opt, nil)
+
+	a.NotError(fs.Parse(nil))
+	err := do(io.Discard)
+	a.Error(err)
+	a.True(strings.Contains(err.Error(), "name"))
+}
+
+func TestBind_required_alias(t *testing.T) {
+	a := assert.New(t, false)
+
+	type options struct {
+		File string `flag:"-f,--file" required:"true"`
+	}
+
+	opt := &options{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	do := Bind(fs, opt, nil)
+
+	// 通过非首个别名传参也应被视为已满足 required
+	a.NotError(fs.Parse([]string{"--file", "x"}))
+	a.NotError(do(io.Discard))
+	a.Equal(opt.File, "x")
+}
+
+func TestBind_required_env(t *testing.T) {
+	a := assert.New(t, false)
+
+	type options struct {
+		File string `flag:"-f,--file" env:"BIND_TEST_FILE" required:"true"`
+	}
+
+	a.NotError(os.Setenv("BIND_TEST_FILE", "env.txt"))
+	defer os.Unsetenv("BIND_TEST_FILE")
+
+	opt := &options{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	do := Bind(fs, opt, nil)
+
+	// 未在命令行中指定，但已通过 env 满足，不应报缺少必要参数
+	a.NotError(fs.Parse(nil))
+	a.NotError(do(io.Discard))
+	a.Equal(opt.File, "env.txt")
+}
+
+func TestBind_badDefault(t *testing.T) {
+	a := assert.New(t, false)
+
+	type options struct {
+		Count int `flag:"--count" default:"notanumber"`
+	}
+
+	opt := &options{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	a.PanicString(func() {
+		Bind(fs, opt, nil)
+	}, "不是合法的 int 值")
+}
+
+func TestCmdOpt_Register(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "usage", nil, notFound)
+
+	v := &bindTestOptions{}
+	opt.Register("cmd", "title", "usage", v, func(w io.Writer) error {
+		_, err := w.Write([]byte(v.File))
+		return err
+	})
+
+	a.NotError(opt.Exec([]string{"cmd", "--name", "n1"}))
+	a.Equal(output.String(), "a.txt")
+}
@@ -26,6 +26,15 @@ type CmdOpt struct {
 	commands    map[string]*command
 	maxCmdLen   int // 记录子命令的最大字符宽度，使输出的命令行可以更加美观。
 
+	// completers 保存了通过 [CmdOpt.RegisterCompleter] 注册的动态补全函数，
+	// 第一层 key 为子命令名称，第二层 key 为参数名称。
+	completers map[string]map[string]func(string) []string
+
+	// middlewares 保存了通过 [CmdOpt.Use] 注册的中间件，按注册顺序应用。
+	middlewares []func(DoFunc) DoFunc
+
+	errorHandler ErrorHandler
+
 	execed bool
 }
 
@@ -74,13 +83,12 @@ func New(output io.Writer, errorHandling flag.ErrorHandling, usageTemplate strin
 	}
 
 	opt := &CmdOpt{
-		cmd: &command{exec: do2exec(do, fs)},
-
 		output:      output,
 		errHandling: errorHandling,
 		notFound:    notFound,
 		commands:    make(map[string]*command, 10),
 	}
+	opt.cmd = &command{exec: do2exec(opt, do, fs)}
 
 	opt.usage = func() string {
 		opt.buildUsage(usageTemplate, fs)
@@ -124,16 +132,80 @@ func (opt *CmdOpt) New(name, title, usage string, cmd CommandFunc) {
 
 	fs.Usage = func() { io.WriteString(opt.Output(), usage) }
 
-	opt.NewPlain(name, title, usage, do2exec(do, fs))
+	opt.NewPlain(name, title, usage, do2exec(opt, do, fs))
+	opt.commands[name].fs = fs
 }
 
-func do2exec(do DoFunc, fs *flag.FlagSet) func(io.Writer, []string) error {
+func do2exec(opt *CmdOpt, do DoFunc, fs *flag.FlagSet) func(io.Writer, []string) error {
 	return func(w io.Writer, args []string) error {
-		if err := fs.Parse(args); err != nil {
+		if err := fs.Parse(expandShortFlags(fs, args)); err != nil {
+			return err
+		}
+		return opt.wrap(do)(w)
+	}
+}
+
+// Use 注册一个中间件，应用于所有通过 [CmdOpt.New] 或 [CmdOpt.Register] 注册的
+// 子命令的 [DoFunc]
+//
+// mw 在每次执行子命令时才会被应用一次，因此同样会作用于 Use 调用之前已经注册的
+// 子命令；多次调用 Use 时，先注册的中间件包裹在外层，即先执行。
+func (opt *CmdOpt) Use(mw func(next DoFunc) DoFunc) {
+	opt.middlewares = append(opt.middlewares, mw)
+}
+
+func (opt *CmdOpt) wrap(do DoFunc) DoFunc {
+	for i := len(opt.middlewares) - 1; i >= 0; i-- {
+		do = opt.middlewares[i](do)
+	}
+	return do
+}
+
+// ErrorHandler 用于统一处理子命令执行过程中返回的错误
+//
+// cmd 为触发错误的子命令名称，根命令（即非子命令模式）为空字符串；err 为原始错误。
+// 返回值将作为 [CmdOpt.Exec] 的返回值，可用于实现着色输出、退出码映射，或统一处理
+// [flag.ErrHelp]。
+type ErrorHandler func(cmd string, err error) error
+
+// SetErrorHandler 设置错误处理函数
+//
+// 不设置该函数时，Exec 保持默认行为：忽略 [flag.ErrHelp]，其它错误原样返回。
+func (opt *CmdOpt) SetErrorHandler(h ErrorHandler) { opt.errorHandler = h }
+
+// NewWithHooks 注册子命令，并在其 [DoFunc] 执行前后调用 pre 和 post 钩子
+//
+// pre 在参数解析完成之后、cmd 生成的 DoFunc 执行之前调用，如果返回错误，则不再
+// 执行后续内容；post 在 DoFunc 执行完成之后调用，无论 DoFunc 是否返回错误都会
+// 执行，可用于日志记录、资源清理等跨子命令的公共逻辑。pre、post 均可以为 nil。
+//
+// 其余参数可参考 [CmdOpt.New]。
+func (opt *CmdOpt) NewWithHooks(name, title, usage string, cmd CommandFunc, pre, post func(io.Writer, []string) error) {
+	wrapped := func(fs *flag.FlagSet) DoFunc {
+		do := cmd(fs)
+
+		return func(w io.Writer) error {
+			args := fs.Args()
+
+			if pre != nil {
+				if err := pre(w, args); err != nil {
+					return err
+				}
+			}
+
+			err := do(w)
+
+			if post != nil {
+				if perr := post(w, args); err == nil {
+					err = perr
+				}
+			}
+
 			return err
 		}
-		return do(w)
 	}
+
+	opt.New(name, title, usage, wrapped)
 }
 
 // NewPlain 添加自行处理参数的子命令
@@ -151,14 +223,66 @@ func (opt *CmdOpt) NewPlain(name, title, usage string, exec func(io.Writer, []st
 	}
 
 	opt.commands[name] = &command{
-		exec:  exec,
-		title: title,
-		usage: usage,
+		exec:    exec,
+		title:   title,
+		usage:   usage,
+		primary: name,
+	}
+
+	// 以双下划线开头的为隐藏命令（参考 [CmdOpt.Commands]），不参与命令列表的对齐宽度计算。
+	if l := len(name); l > opt.maxCmdLen && !strings.HasPrefix(name, "__") {
+		opt.maxCmdLen = l
+	}
+}
+
+// NewGroup 注册一个子命令分组
+//
+// 分组本身也是一个 [CmdOpt]，可以继续调用其 New、NewPlain 或 NewGroup 注册下一级的
+// 子命令，由此可以构成类似 git remote add origin ... 这种多级命令行结构。
+//
+// 分组默认继承当前实例的 output、errHandling 和 notFound，如有需要，
+// 可通过返回值的 [CmdOpt.SetOutput] 等方法覆盖。
+//
+// name, title 和 usage 参数可参考 [CmdOpt.New]，usage 中的 {{commands}}
+// 占位符会被替换为该分组下一级的子命令列表，仅支持 {{commands}}，不支持 {{flags}}。
+func (opt *CmdOpt) NewGroup(name, title, usage string) *CmdOpt {
+	if opt.execed {
+		panic("程序已经运行，不可再添加子命令！")
+	}
+	if name == "" {
+		panic("参数 name 不能为空")
+	}
+	if _, found := opt.commands[name]; found {
+		panic(fmt.Sprintf("存在相同名称的子命令：%s", name))
+	}
+
+	child := &CmdOpt{
+		output:      opt.output,
+		errHandling: opt.errHandling,
+		notFound:    opt.notFound,
+		commands:    make(map[string]*command, 10),
+	}
+	child.cmd = &command{exec: func(w io.Writer, args []string) error {
+		_, err := io.WriteString(w, child.usage())
+		return err
+	}}
+	child.usage = func() string {
+		child.buildUsage(usage, flag.NewFlagSet(name, child.errHandling))
+		return child.cmd.usage
+	}
+
+	opt.commands[name] = &command{
+		exec:    func(w io.Writer, args []string) error { return child.dispatch(args) },
+		title:   title,
+		group:   child,
+		primary: name,
 	}
 
 	if l := len(name); l > opt.maxCmdLen {
 		opt.maxCmdLen = l
 	}
+
+	return child
 }
 
 func getFlags(fs *flag.FlagSet) string {
@@ -181,24 +305,31 @@ func (opt *CmdOpt) Exec(args []string) error {
 	}
 	opt.execed = true
 
+	return opt.dispatch(args)
+}
+
+// dispatch 实际的命令分发逻辑，被 [CmdOpt.Exec] 以及 [CmdOpt.NewGroup] 注册的
+// 子分组共用，分组本身不受 execed 只能调用一次的限制。
+func (opt *CmdOpt) dispatch(args []string) error {
 	if len(args) == 0 {
-		return opt.cmd.exec(opt.Output(), nil)
+		return opt.handleError("", opt.cmd.exec(opt.Output(), nil))
 	}
 
 	name := args[0]
 	if name[0] == '-' { // 第一个即为参数，表示为非子命令模式
-		if err := opt.cmd.exec(opt.Output(), args); err != nil && !errors.Is(err, flag.ErrHelp) {
-			return err
-		}
-		return nil
+		return opt.handleError("", opt.cmd.exec(opt.Output(), args))
 	}
 
 	if cmd, found := opt.commands[name]; found {
-		return cmd.exec(opt.Output(), args[1:])
+		return opt.handleError(name, cmd.exec(opt.Output(), args[1:]))
 	}
 
 	if opt.notFound != nil {
-		_, err := io.WriteString(opt.Output(), opt.notFound(name))
+		msg := opt.notFound(name)
+		if suggestions := opt.suggest(name); len(suggestions) > 0 {
+			msg = fmt.Sprintf("Did you mean: %s?\n", strings.Join(suggestions, ", ")) + msg
+		}
+		_, err := io.WriteString(opt.Output(), msg)
 		return err
 	}
 
@@ -206,6 +337,23 @@ func (opt *CmdOpt) Exec(args []string) error {
 	return err
 }
 
+// handleError 统一处理子命令执行后的错误
+//
+// 设置了 [CmdOpt.SetErrorHandler] 时交由其处理，包括 [flag.ErrHelp]；
+// 否则保持默认行为，忽略 flag.ErrHelp，其它错误原样返回。
+func (opt *CmdOpt) handleError(cmd string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if opt.errorHandler != nil {
+		return opt.errorHandler(cmd, err)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
 // Usage 整个项目的使用说明内容
 //
 // 基于 [New] 的 usage 参数，里面的占位符会被真实的内容所覆盖。
@@ -216,9 +364,10 @@ func (opt *CmdOpt) buildUsage(tpl string, fs *flag.FlagSet) {
 	flags := getFlags(fs)
 	var commands bytes.Buffer
 	for _, name := range opt.Commands() { // 保证顺序相同
-		title, _, _ := opt.Command(name)
-		cmdName := name + strings.Repeat(" ", opt.maxCmdLen+3-len(name)) // 为子命令名称留下的最小长度
-		fmt.Fprintf(&commands, "  %s%s\n", cmdName, title)
+		cmd := opt.commands[name]
+		display := displayName(name, cmd.aliases)                              // 包含别名，形如 name, a1, a2
+		cmdName := display + strings.Repeat(" ", opt.maxCmdLen+3-len(display)) // 为子命令名称留下的最小长度
+		fmt.Fprintf(&commands, "  %s%s\n", cmdName, cmd.title)
 	}
 
 	usage := strings.ReplaceAll(tpl, "{{flags}}", flags)
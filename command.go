@@ -8,15 +8,23 @@ import (
 	"flag"
 	"io"
 	"sort"
+	"strings"
 )
 
 type command struct {
-	exec  func(io.Writer, []string) error
-	title string
-	usage string
+	exec    func(io.Writer, []string) error
+	title   string
+	usage   string
+	group   *CmdOpt       // 不为空表示该命令由 [CmdOpt.NewGroup] 注册，是一个子命令分组
+	fs      *flag.FlagSet // 由 [CmdOpt.New] 注册的命令会记录其 FlagSet，供补全等功能使用
+	primary string        // 该命令注册时使用的名称，别名也指向同一个 *command，以此区分
+	aliases []string      // 通过 [CmdOpt.Alias] 添加的别名
 }
 
 // Help 注册 help 子命令
+//
+// 参数可以是以空格或点号分隔的路径，比如 help remote add 或 help remote.add，
+// 用于查看通过 [CmdOpt.NewGroup] 注册的嵌套子命令的使用说明。
 func Help(opt *CmdOpt, name, title, usage string) {
 	f := func(fs *flag.FlagSet) DoFunc {
 		return func(output io.Writer) error {
@@ -25,14 +33,33 @@ func Help(opt *CmdOpt, name, title, usage string) {
 				return err
 			}
 
-			name := fs.Arg(0)
-			if _, usage, found := opt.Command(name); found {
-				_, err := io.WriteString(output, usage)
+			var path []string
+			for i := 0; i < fs.NArg(); i++ {
+				path = append(path, strings.Split(fs.Arg(i), ".")...)
+			}
+
+			cur := opt
+			for i, name := range path {
+				cmd, found := cur.commands[name]
+				if !found {
+					_, err := io.WriteString(output, opt.notFound(name))
+					return err
+				}
+
+				if cmd.group != nil {
+					cur = cmd.group
+					if i == len(path)-1 {
+						_, err := io.WriteString(output, cur.usage())
+						return err
+					}
+					continue
+				}
+
+				_, err := io.WriteString(output, cmd.usage)
 				return err
 			}
 
-			_, err := io.WriteString(output, opt.notFound(name))
-			return err
+			return nil
 		}
 	}
 
@@ -40,10 +67,15 @@ func Help(opt *CmdOpt, name, title, usage string) {
 }
 
 // Commands 返回所有的子命令
+//
+// 别名不会出现在返回结果中，但会在 [CmdOpt.Usage] 的 {{commands}} 列表中与原名一同列出。
+// 以双下划线开头的子命令（比如 __complete）被视为内部使用的隐藏命令，不会出现在结果中。
 func (opt *CmdOpt) Commands() []string {
 	keys := make([]string, 0, len(opt.commands))
-	for key := range opt.commands {
-		keys = append(keys, key)
+	for key, cmd := range opt.commands {
+		if cmd.primary == key && !strings.HasPrefix(key, "__") { // 排除别名对应的 key 及隐藏命令
+			keys = append(keys, key)
+		}
 	}
 
 	sort.Strings(keys) // TODO(go1.21): slices.Sort
@@ -51,9 +83,14 @@ func (opt *CmdOpt) Commands() []string {
 }
 
 // Command 返回指定的命令的说明
+//
+// usage 不包含 [CmdOpt.New] 为打印到终端而补上的末尾换行符。
 func (opt *CmdOpt) Command(name string) (title, usage string, found bool) {
 	if cmd, found := opt.commands[name]; found {
-		return cmd.title, cmd.usage, true
+		if cmd.group != nil {
+			return cmd.title, strings.TrimSuffix(cmd.group.usage(), "\n"), true
+		}
+		return cmd.title, strings.TrimSuffix(cmd.usage, "\n"), true
 	}
 	return "", "", false
 }
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// completeHiddenArg 是 shell 补全脚本回调程序时使用的隐藏子命令名称
+const completeHiddenArg = "__complete"
+
+// completionScripts 以 {{prog}} 作为可执行文件名称的占位符
+var completionScripts = map[string]string{
+	"bash": `_{{prog}}_complete() {
+    local words
+    words=$({{prog}} __complete "${COMP_WORDS[@]:1:COMP_CWORD}")
+    COMPREPLY=($(compgen -W "$words" -- "${COMP_WORDS[COMP_CWORD]}"))
+}
+complete -F _{{prog}}_complete {{prog}}
+`,
+
+	"zsh": `#compdef {{prog}}
+_{{prog}}() {
+    local -a words
+    words=(${(f)"$({{prog}} __complete ${words[2,-1]})"})
+    _describe 'command' words
+}
+compdef _{{prog}} {{prog}}
+`,
+
+	"fish": `function __{{prog}}_complete
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    {{prog}} __complete $tokens (commandline -ct)
+end
+complete -c {{prog}} -f -a '(__{{prog}}_complete)'
+`,
+
+	"powershell": `Register-ArgumentCompleter -Native -CommandName {{prog}} -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = $commandAst.ToString().Split(' ') | Select-Object -Skip 1
+    & {{prog}} __complete $words | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`,
+}
+
+// Completion 注册 completion 子命令，用于生成 bash、zsh、fish 和 powershell 的补全脚本
+//
+// name 为该子命令的名称，通常为 completion；生成的脚本通过隐藏的 __complete 子命令
+// 回调自身以获取动态的补全内容，具体逻辑见 [Complete]。该隐藏子命令由本函数一并注册。
+func Completion(opt *CmdOpt, name, title string) {
+	usage := "completion bash|zsh|fish|powershell\n"
+	opt.NewPlain(name, title, usage, func(w io.Writer, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("缺少 shell 参数，可选值为 bash、zsh、fish 或 powershell")
+		}
+
+		tpl, found := completionScripts[args[0]]
+		if !found {
+			return fmt.Errorf("不支持的 shell：%s", args[0])
+		}
+
+		prog := filepath.Base(os.Args[0])
+		_, err := io.WriteString(w, strings.ReplaceAll(tpl, "{{prog}}", prog))
+		return err
+	})
+
+	opt.NewPlain(completeHiddenArg, "", "", func(w io.Writer, args []string) error {
+		_, err := io.WriteString(w, strings.Join(Complete(opt, args), "\n"))
+		return err
+	})
+}
+
+// RegisterCompleter 为指定子命令的指定参数注册动态补全函数
+//
+// cmdName 为通过 [CmdOpt.New] 注册的子命令名称；flagName 为该子命令 FlagSet 中
+// 已注册的参数名称（不带 - 前缀）；fn 根据用户已输入的参数前缀返回候选值列表，
+// 可用于文件路径、远程分支名称等动态场景。
+func (opt *CmdOpt) RegisterCompleter(cmdName, flagName string, fn func(prefix string) []string) {
+	if opt.completers == nil {
+		opt.completers = make(map[string]map[string]func(string) []string, 10)
+	}
+
+	m, found := opt.completers[cmdName]
+	if !found {
+		m = make(map[string]func(string) []string, 5)
+		opt.completers[cmdName] = m
+	}
+	m[flagName] = fn
+}
+
+// Complete 根据已输入的参数 args 返回补全候选项
+//
+// args 为 shell 传递的、已去除隐藏的 __complete 标记的参数列表，该方法由
+// [Completion] 生成的脚本回调使用，一般无需用户直接调用。
+func Complete(opt *CmdOpt, args []string) []string {
+	cur := opt
+	var cmdName string
+	var cmd *command
+
+	for len(args) > 0 {
+		name := args[0]
+
+		if strings.HasPrefix(name, "-") {
+			break
+		}
+
+		c, found := cur.commands[name]
+		if !found {
+			if len(args) == 1 { // 最后一个 token，视为待补全的子命令前缀
+				return completeNames(cur, name)
+			}
+			return nil
+		}
+
+		cmdName, cmd = name, c
+		args = args[1:]
+
+		if c.group != nil {
+			cur = c.group
+			cmdName, cmd = "", nil
+			continue
+		}
+
+		break
+	}
+
+	if cmd == nil {
+		return completeNames(cur, "")
+	}
+	return completeFlags(cur, cmdName, cmd, args)
+}
+
+func completeNames(opt *CmdOpt, prefix string) []string {
+	names := make([]string, 0, len(opt.commands))
+	for _, name := range opt.Commands() {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func completeFlags(opt *CmdOpt, cmdName string, cmd *command, args []string) []string {
+	last := ""
+	if len(args) > 0 {
+		last = args[len(args)-1]
+	}
+
+	if !strings.HasPrefix(last, "-") {
+		return nil
+	}
+	prefix := strings.TrimLeft(last, "-")
+
+	if i := strings.IndexByte(prefix, '='); i >= 0 {
+		flagName, valuePrefix := prefix[:i], prefix[i+1:]
+		if fn := opt.completer(cmdName, flagName); fn != nil {
+			return fn(valuePrefix)
+		}
+		return nil
+	}
+
+	if cmd.fs == nil {
+		return nil
+	}
+
+	names := make([]string, 0, 10)
+	cmd.fs.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, prefix) {
+			names = append(names, "-"+f.Name)
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+func (opt *CmdOpt) completer(cmdName, flagName string) func(string) []string {
+	if m, found := opt.completers[cmdName]; found {
+		return m[flagName]
+	}
+	return nil
+}
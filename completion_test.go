@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestComplete(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "usage", nil, notFound)
+
+	opt.New("test1", "test1 title", "usage", func(fs *flag.FlagSet) DoFunc {
+		fs.String("file", "", "file usage")
+		fs.Bool("verbose", false, "verbose usage")
+		return func(io.Writer) error { return nil }
+	})
+	opt.New("test2", "test2 title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(io.Writer) error { return nil }
+	})
+
+	a.Equal(Complete(opt, nil), []string{"test1", "test2"})
+	a.Equal(Complete(opt, []string{"te"}), []string{"test1", "test2"})
+	a.Equal(Complete(opt, []string{"test1", "-"}), []string{"-file", "-verbose"})
+	a.Equal(Complete(opt, []string{"test1", "-v"}), []string{"-verbose"})
+
+	opt.RegisterCompleter("test1", "file", func(prefix string) []string {
+		return []string{prefix + "a.txt", prefix + "b.txt"}
+	})
+	a.Equal(Complete(opt, []string{"test1", "-file="}), []string{"a.txt", "b.txt"})
+}
+
+func TestComplete_group(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "usage", nil, notFound)
+	remote := opt.NewGroup("remote", "remote title", "remote usage")
+	remote.New("add", "add title", "usage", func(fs *flag.FlagSet) DoFunc {
+		fs.String("branch", "", "branch usage")
+		return func(io.Writer) error { return nil }
+	})
+	remote.RegisterCompleter("add", "branch", func(prefix string) []string {
+		return []string{prefix + "main"}
+	})
+
+	a.Equal(Complete(opt, []string{"remote", "add", "-branch="}), []string{"main"})
+}
+
+func TestCompletion(t *testing.T) {
+	a := assert.New(t, false)
+
+	newOpt := func() *CmdOpt {
+		opt := New(new(bytes.Buffer), flag.PanicOnError, "usage", nil, notFound)
+		Completion(opt, "completion", "completion title")
+		return opt
+	}
+
+	opt := newOpt()
+	a.NotError(opt.Exec([]string{"completion", "bash"}))
+	a.True(opt.Output().(*bytes.Buffer).Len() > 0)
+
+	// __complete 子命令不出现在子命令列表中
+	a.Equal(opt.Commands(), []string{"completion"})
+
+	// __complete 子命令回调 Complete 并输出候选项
+	opt = newOpt()
+	a.NotError(opt.Exec([]string{"__complete", "compl"}))
+	a.Equal(opt.Output().(*bytes.Buffer).String(), "completion")
+}
+
+func TestCompletion_maxCmdLen(t *testing.T) {
+	a := assert.New(t, false)
+
+	opt := New(new(bytes.Buffer), flag.PanicOnError, "usage\n{{commands}}", nil, notFound)
+	opt.New("ls", "ls title", "ls usage", func(fs *flag.FlagSet) DoFunc {
+		return func(io.Writer) error { return nil }
+	})
+	Completion(opt, "c", "c title")
+
+	// __complete 比 ls、c 都长，但属于隐藏命令，不应撑开对齐宽度
+	a.True(strings.Contains(opt.Usage(), "  ls   ls title\n"))
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestCmdOpt_NewGroup(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.PanicOnError, "header\ncommands\n{{commands}}\nfooter", nil, notFound)
+
+	remote := opt.NewGroup("remote", "remote title", "remote usage\n{{commands}}")
+	a.NotNil(remote)
+
+	remote.New("add", "add title", "add usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte("added:" + fs.Arg(0)))
+			return err
+		}
+	})
+
+	a.NotError(opt.Exec([]string{"remote", "add", "origin"}))
+	a.Equal(output.String(), "added:origin")
+}
+
+func TestCmdOpt_NewGroup_help(t *testing.T) {
+	a := assert.New(t, false)
+
+	newOpt := func() (*CmdOpt, *bytes.Buffer) {
+		output := new(bytes.Buffer)
+		opt := New(output, flag.PanicOnError, "header\ncommands\n{{commands}}\nfooter", nil, notFound)
+
+		remote := opt.NewGroup("remote", "remote title", "remote usage\n{{commands}}")
+		remote.New("add", "add title", "add usage\n", func(fs *flag.FlagSet) DoFunc {
+			return func(w io.Writer) error { return nil }
+		})
+
+		Help(opt, "help", "help title", "help usage")
+		return opt, output
+	}
+
+	opt, output := newOpt()
+	a.NotError(opt.Exec([]string{"help", "remote", "add"}))
+	a.Equal(output.String(), "add usage\n")
+
+	opt, output = newOpt()
+	a.NotError(opt.Exec([]string{"help", "remote"}))
+	a.True(strings.Contains(output.String(), "remote usage"))
+	a.True(strings.Contains(output.String(), "add"))
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestCmdOpt_Use(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+
+	opt.New("cmd", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte("cmd"))
+			return err
+		}
+	})
+
+	opt.Use(func(next DoFunc) DoFunc {
+		return func(w io.Writer) error {
+			io.WriteString(w, "[")
+			if err := next(w); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, "]")
+			return err
+		}
+	})
+
+	a.NotError(opt.Exec([]string{"cmd"}))
+	a.Equal(output.String(), "[cmd]")
+}
+
+func TestCmdOpt_NewWithHooks(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+
+	pre := func(w io.Writer, args []string) error {
+		_, err := w.Write([]byte("pre-"))
+		return err
+	}
+	post := func(w io.Writer, args []string) error {
+		_, err := w.Write([]byte("-post"))
+		return err
+	}
+
+	opt.NewWithHooks("cmd", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte("do"))
+			return err
+		}
+	}, pre, post)
+
+	a.NotError(opt.Exec([]string{"cmd"}))
+	a.Equal(output.String(), "pre-do-post")
+}
+
+func TestCmdOpt_dispatch_ErrHelp(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 未设置 SetErrorHandler 时，子命令分支也应忽略 flag.ErrHelp，与根命令分支保持一致
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+	opt.New("cmd", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error { return nil }
+	})
+
+	a.NotError(opt.Exec([]string{"cmd", "-h"}))
+}
+
+func TestCmdOpt_SetErrorHandler(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+
+	wantErr := errors.New("boom")
+	opt.New("cmd", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		return func(w io.Writer) error { return wantErr }
+	})
+
+	var handled string
+	opt.SetErrorHandler(func(cmd string, err error) error {
+		handled = cmd
+		return nil
+	})
+
+	a.NotError(opt.Exec([]string{"cmd"}))
+	a.Equal(handled, "cmd")
+}
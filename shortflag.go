@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// boolFlag 与 flag 包内部未导出的 boolFlag 接口等价，用于判断某个参数是否为布尔类型。
+type boolFlag interface {
+	flag.Value
+	IsBoolFlag() bool
+}
+
+// expandShortFlags 将形如 -abc 的组合短参数展开为 -a -b -c
+//
+// 仅当 a、b、c 均已在 fs 上注册为布尔型参数时才会展开，否则原样保留 arg，
+// 以兼容 -n10 这种值紧跟在短参数之后的写法。遇到 -- 之后的内容不作任何处理。
+func expandShortFlags(fs *flag.FlagSet, args []string) []string {
+	out := make([]string, 0, len(args))
+
+	for i, arg := range args {
+		if arg == "--" {
+			out = append(out, args[i:]...)
+			break
+		}
+
+		if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' {
+			names := strings.Split(arg[1:], "")
+			expanded := true
+			for _, name := range names {
+				if !isBoolFlag(fs, name) {
+					expanded = false
+					break
+				}
+			}
+
+			if expanded {
+				for _, name := range names {
+					out = append(out, "-"+name)
+				}
+				continue
+			}
+		}
+
+		out = append(out, arg)
+	}
+
+	return out
+}
+
+func isBoolFlag(fs *flag.FlagSet, name string) bool {
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	bf, ok := f.Value.(boolFlag)
+	return ok && bf.IsBoolFlag()
+}
+
+// ShortAlias 为子命令 cmd 下名为 long 的参数注册一个短名称 short
+//
+// 注册之后，-long 和 -short 可以互换使用，指向同一个变量。cmd 必须是通过
+// [CmdOpt.New] 注册的子命令，long 必须是该子命令已注册的参数名称。
+func (opt *CmdOpt) ShortAlias(cmd, long, short string) {
+	c, found := opt.commands[cmd]
+	if !found {
+		panic(fmt.Sprintf("不存在名为 %s 的子命令", cmd))
+	}
+	if c.fs == nil {
+		panic(fmt.Sprintf("子命令 %s 未通过 New 注册，不支持添加参数别名", cmd))
+	}
+
+	f := c.fs.Lookup(long)
+	if f == nil {
+		panic(fmt.Sprintf("子命令 %s 不存在名为 %s 的参数", cmd, long))
+	}
+	if c.fs.Lookup(short) != nil {
+		panic(fmt.Sprintf("子命令 %s 已存在名为 %s 的参数", cmd, short))
+	}
+
+	c.fs.Var(f.Value, short, f.Usage)
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+
+package cmdopt
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestCmdOpt_posixShortFlags(t *testing.T) {
+	a := assert.New(t, false)
+
+	newOpt := func(a *assert.Assertion) (*CmdOpt, *bytes.Buffer, *bool, *bool, *bool) {
+		output := new(bytes.Buffer)
+		var l, t, r bool
+
+		opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+		opt.New("ls", "ls title", "usage", func(fs *flag.FlagSet) DoFunc {
+			fs.BoolVar(&l, "l", false, "long")
+			fs.BoolVar(&t, "t", false, "time")
+			fs.BoolVar(&r, "r", false, "reverse")
+			return func(w io.Writer) error {
+				_, err := w.Write([]byte("ok"))
+				return err
+			}
+		})
+
+		return opt, output, &l, &t, &r
+	}
+
+	opt, output, l, tm, r := newOpt(a)
+	a.NotError(opt.Exec([]string{"ls", "-ltr"}))
+	a.Equal(output.String(), "ok").
+		True(*l).True(*tm).True(*r)
+
+	// -- 之后的内容保持原样，不再作为参数解析
+	opt, output, _, _, _ = newOpt(a)
+	a.NotError(opt.Exec([]string{"ls", "--", "-ltr"}))
+	a.Equal(output.String(), "ok")
+}
+
+func TestCmdOpt_ShortAlias(t *testing.T) {
+	a := assert.New(t, false)
+
+	output := new(bytes.Buffer)
+	opt := New(output, flag.ContinueOnError, "usage", nil, notFound)
+
+	var file string
+	opt.New("cmd", "title", "usage", func(fs *flag.FlagSet) DoFunc {
+		fs.StringVar(&file, "file", "", "file usage")
+		return func(w io.Writer) error { return nil }
+	})
+
+	opt.ShortAlias("cmd", "file", "f")
+
+	a.NotError(opt.Exec([]string{"cmd", "-f", "a.txt"}))
+	a.Equal(file, "a.txt")
+}